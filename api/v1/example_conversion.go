@@ -0,0 +1,5 @@
+package v1
+
+// Hub marks Example as the conversion hub (storage version); spoke versions
+// such as v1beta1 implement conversion.Convertible against it.
+func (*Example) Hub() {}
@@ -2,9 +2,25 @@
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Phase values reported on ExampleStatus.Phase.
+const (
+	PhasePending = "Pending"
+	PhaseReady   = "Ready"
+	PhaseFailed  = "Failed"
+)
+
+// Condition types set on ExampleStatus.Conditions.
+const (
+	ConditionAvailable      = "Available"
+	ConditionProgressing    = "Progressing"
+	ConditionDegraded       = "Degraded"
+	ConditionTelemetryReady = "TelemetryReady"
+)
+
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
@@ -15,18 +31,127 @@ type ExampleSpec struct {
 
 	// Foo is an example field of Example. Edit example_types.go to remove/update
 	Name string `json:"name"`
-	Size int32  `json:"size"`
+
+	// Image is the container image run by the workload Pods.
+	Image string `json:"image"`
+
+	// ImagePullPolicy defines how the kubelet pulls Image.
+	//+kubebuilder:default=IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+
+	// Replicas is the desired number of Pods backing the workload.
+	//+kubebuilder:default=1
+	//+kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources describes the compute resource requirements for the workload container.
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains the Pods to nodes matching the given labels.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allows the Pods to schedule onto nodes with matching taints.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity describes the scheduling constraints for the Pods.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// Env lists the environment variables to set in the workload container.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// ServiceAccountName is the name of the ServiceAccount used to run the Pods.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// Telemetry configures an OpenTelemetry Collector sidecar for the workload.
+	Telemetry *TelemetrySpec `json:"telemetry,omitempty"`
+
+	// Metrics configures a ServiceMonitor scraping the workload's metrics endpoint.
+	Metrics *MetricsSpec `json:"metrics,omitempty"`
+}
+
+// MetricsSpec configures a Prometheus ServiceMonitor for the reconciled workload.
+type MetricsSpec struct {
+	// Enabled creates a ServiceMonitor for the workload when the Prometheus
+	// Operator CRDs are present in the cluster.
+	Enabled bool `json:"enabled"`
+
+	// Port is the name of the Service port exposing the metrics endpoint.
+	//+kubebuilder:default=metrics
+	Port string `json:"port,omitempty"`
+
+	// Path is the HTTP path the metrics endpoint is served on.
+	//+kubebuilder:default="/metrics"
+	Path string `json:"path,omitempty"`
+
+	// Interval is the scrape interval, in Prometheus duration format (e.g. "30s").
+	//+kubebuilder:default="30s"
+	Interval string `json:"interval,omitempty"`
+}
+
+// TelemetrySpec configures an OpenTelemetry Collector sidecar injected into
+// the reconciled workload's Pod template.
+type TelemetrySpec struct {
+	// Enabled turns the collector sidecar on or off.
+	Enabled bool `json:"enabled"`
+
+	// Endpoint is the OTLP exporter endpoint the sidecar forwards to.
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Protocol is the OTLP wire protocol used to reach Endpoint.
+	//+kubebuilder:validation:Enum=grpc;http
+	//+kubebuilder:default=grpc
+	Protocol string `json:"protocol,omitempty"`
+
+	// Headers maps an OTLP export HTTP/gRPC header name to the key that holds
+	// its value in the Secret named by SecretRef (e.g. api-key -> Secret key
+	// "api-key"), so secret values never appear directly in the spec. Each
+	// value is exposed to the collector sidecar as the environment variable
+	// OTEL_HEADER_<name>; the generated collector config does not add them as
+	// headers on the OTLP exporter.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// SecretRef names the Secret in the same namespace that Headers values are read from.
+	SecretRef string `json:"secretRef,omitempty"`
+
+	// SampleRatio is the fraction (0.0-1.0) of traces kept by the collector's
+	// probabilistic_sampler processor, which runs ahead of the batch processor
+	// in the traces pipeline. Defaults to 1.0 (no sampling) when unset.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=1
+	SampleRatio *float64 `json:"sampleRatio,omitempty"`
 }
 
 // ExampleStatus defines the observed state of Example
 type ExampleStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
-	Name string `json:"name"`
+	// Phase is a high-level summary of where the Example is in its lifecycle.
+	//+kubebuilder:validation:Enum=Pending;Ready;Failed
+	Phase string `json:"phase,omitempty"`
+
+	// Conditions represent the latest available observations of the Example's state.
+	//+patchStrategy=merge
+	//+patchMergeKey=type
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// ObservedGeneration is the most recent generation observed by the controller.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ReadyReplicas is the number of Pods backing the workload that are ready.
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Selector is the label selector used by the scale subresource, in
+	// serialized form as understood by kubectl and the HPA.
+	Selector string `json:"selector,omitempty"`
 }
 
 //+kubebuilder:object:root=true
+//+kubebuilder:storageversion
 //+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.readyReplicas,selectorpath=.status.selector
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=".status.readyReplicas"
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 
 // Example is the Schema for the examples API
 type Example struct {
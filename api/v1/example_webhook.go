@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var examplelog = logf.Log.WithName("example-resource")
+
+//+kubebuilder:webhook:path=/convert,mutating=false,failurePolicy=fail,sideEffects=None,groups=example.example.com,resources=examples,verbs=create;update,versions=v1;v1beta1,name=vexampleconversion.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:conversion
+
+// SetupWebhookWithManager registers the defaulting and validating webhooks
+// for this version (v1) of Example with the Manager. Example also implements
+// conversion.Hub (see example_conversion.go), so the same webhook server
+// serves /convert for this GroupKind.
+func (r *Example) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-example-example-com-v1-example,mutating=true,failurePolicy=fail,sideEffects=None,groups=example.example.com,resources=examples,verbs=create;update,versions=v1,name=mexample.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &Example{}
+
+// Default implements webhook.Defaulter so that an empty ImagePullPolicy is
+// defaulted to IfNotPresent, mirroring the +kubebuilder:default marker for
+// clients that skip CRD defaulting (e.g. server-side apply dry-runs).
+func (r *Example) Default() {
+	examplelog.Info("default", "name", r.Name)
+	if r.Spec.ImagePullPolicy == "" {
+		r.Spec.ImagePullPolicy = corev1.PullIfNotPresent
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-example-example-com-v1-example,mutating=false,failurePolicy=fail,sideEffects=None,groups=example.example.com,resources=examples,verbs=create;update,versions=v1,name=vexample.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &Example{}
+
+// ValidateCreate implements webhook.Validator.
+func (r *Example) ValidateCreate() (admission.Warnings, error) {
+	examplelog.Info("validate create", "name", r.Name)
+	return nil, r.validate()
+}
+
+// ValidateUpdate implements webhook.Validator. In addition to the create
+// rules, it forbids mutating ServiceAccountName once set.
+func (r *Example) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	examplelog.Info("validate update", "name", r.Name)
+	oldExample, ok := old.(*Example)
+	if !ok {
+		return nil, fmt.Errorf("expected an Example but got a %T", old)
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	if oldExample.Spec.ServiceAccountName != "" && r.Spec.ServiceAccountName != oldExample.Spec.ServiceAccountName {
+		return nil, field.Invalid(field.NewPath("spec", "serviceAccountName"), r.Spec.ServiceAccountName, "field is immutable")
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator. Example has nothing to check on delete.
+func (r *Example) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (r *Example) validate() error {
+	if r.Spec.Image == "" {
+		return field.Required(field.NewPath("spec", "image"), "image must not be empty")
+	}
+	if r.Spec.Replicas != nil && *r.Spec.Replicas < 0 {
+		return field.Invalid(field.NewPath("spec", "replicas"), *r.Spec.Replicas, "must be greater than or equal to 0")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	v1 "github.com/VishalGawade1/operator-pattern-master/api/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// v1DataAnnotation stores the v1-only spec fields that have no v1beta1
+// equivalent, so a v1beta1 client's read-modify-write round trip does not
+// silently zero them out. It is set by ConvertFrom and consumed (and
+// removed) by ConvertTo.
+const v1DataAnnotation = "example.example.com/v1-data"
+
+// v1OnlyFields holds the ExampleSpec fields introduced in v1 that v1beta1
+// has no representation for.
+type v1OnlyFields struct {
+	Image              string                      `json:"image,omitempty"`
+	ImagePullPolicy    corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
+	Resources          corev1.ResourceRequirements `json:"resources,omitempty"`
+	NodeSelector       map[string]string           `json:"nodeSelector,omitempty"`
+	Tolerations        []corev1.Toleration         `json:"tolerations,omitempty"`
+	Affinity           *corev1.Affinity            `json:"affinity,omitempty"`
+	Env                []corev1.EnvVar             `json:"env,omitempty"`
+	ServiceAccountName string                      `json:"serviceAccountName,omitempty"`
+	Telemetry          *v1.TelemetrySpec           `json:"telemetry,omitempty"`
+	Metrics            *v1.MetricsSpec             `json:"metrics,omitempty"`
+}
+
+// ConvertTo converts this v1beta1 Example to the storage version (v1).
+func (src *Example) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1.Example)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec.Name = src.Spec.Name
+
+	replicas := src.Spec.Size
+	dst.Spec.Replicas = &replicas
+
+	if err := restoreV1OnlyFields(src, dst); err != nil {
+		return fmt.Errorf("restoring v1-only fields: %w", err)
+	}
+
+	return nil
+}
+
+// ConvertFrom converts from the storage version (v1) to this v1beta1 Example.
+func (dst *Example) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1.Example)
+
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+	dst.Spec.Name = src.Spec.Name
+
+	if src.Spec.Replicas != nil {
+		dst.Spec.Size = *src.Spec.Replicas
+	}
+
+	if err := preserveV1OnlyFields(src, dst); err != nil {
+		return fmt.Errorf("preserving v1-only fields: %w", err)
+	}
+
+	return nil
+}
+
+// preserveV1OnlyFields stashes the v1-only spec fields as JSON in an
+// annotation on dst, so they survive a v1beta1 client's read-modify-write cycle.
+func preserveV1OnlyFields(src *v1.Example, dst *Example) error {
+	data, err := json.Marshal(v1OnlyFields{
+		Image:              src.Spec.Image,
+		ImagePullPolicy:    src.Spec.ImagePullPolicy,
+		Resources:          src.Spec.Resources,
+		NodeSelector:       src.Spec.NodeSelector,
+		Tolerations:        src.Spec.Tolerations,
+		Affinity:           src.Spec.Affinity,
+		Env:                src.Spec.Env,
+		ServiceAccountName: src.Spec.ServiceAccountName,
+		Telemetry:          src.Spec.Telemetry,
+		Metrics:            src.Spec.Metrics,
+	})
+	if err != nil {
+		return err
+	}
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	dst.Annotations[v1DataAnnotation] = string(data)
+	return nil
+}
+
+// restoreV1OnlyFields reads the v1-only spec fields back out of the
+// annotation preserveV1OnlyFields wrote, if present, and removes it from dst
+// so it never leaks into the stored v1 object.
+func restoreV1OnlyFields(src *Example, dst *v1.Example) error {
+	raw, ok := src.Annotations[v1DataAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var fields v1OnlyFields
+	if err := json.Unmarshal([]byte(raw), &fields); err != nil {
+		return err
+	}
+
+	dst.Spec.Image = fields.Image
+	dst.Spec.ImagePullPolicy = fields.ImagePullPolicy
+	dst.Spec.Resources = fields.Resources
+	dst.Spec.NodeSelector = fields.NodeSelector
+	dst.Spec.Tolerations = fields.Tolerations
+	dst.Spec.Affinity = fields.Affinity
+	dst.Spec.Env = fields.Env
+	dst.Spec.ServiceAccountName = fields.ServiceAccountName
+	dst.Spec.Telemetry = fields.Telemetry
+	dst.Spec.Metrics = fields.Metrics
+
+	delete(dst.Annotations, v1DataAnnotation)
+	return nil
+}
@@ -0,0 +1,48 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExampleSpec defines the desired state of Example in the v1beta1 API.
+//
+// This version predates the workload fields added in v1 (Image, Resources,
+// ...); it is kept for existing clients and is converted to/from v1 by
+// example_conversion.go. v1 is the storage version.
+type ExampleSpec struct {
+	// Name is the name of the Example. It maps to the v1 Name field.
+	Name string `json:"name"`
+
+	// Size is the desired number of replicas. It maps to the v1 Replicas field.
+	Size int32 `json:"size"`
+}
+
+// ExampleStatus defines the observed state of Example in the v1beta1 API.
+type ExampleStatus struct {
+	Name string `json:"name"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// Example is the Schema for the examples API
+type Example struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExampleSpec   `json:"spec,omitempty"`
+	Status ExampleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ExampleList contains a list of Example
+type ExampleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Example `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Example{}, &ExampleList{})
+}
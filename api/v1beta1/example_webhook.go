@@ -0,0 +1,18 @@
+package v1beta1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+//+kubebuilder:webhook:path=/convert,mutating=false,failurePolicy=fail,sideEffects=None,groups=example.example.com,resources=examples,verbs=create;update,versions=v1;v1beta1,name=vexampleconversion.kb.io,admissionReviewVersions=v1
+//+kubebuilder:webhook:conversion
+
+// SetupWebhookWithManager registers the conversion webhook for this version
+// (v1beta1) of Example with the Manager. Example implements
+// conversion.Convertible (see example_conversion.go), so the controller-runtime
+// webhook server serves /convert for this GroupKind automatically.
+func (r *Example) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
@@ -0,0 +1,474 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	examplev1 "github.com/VishalGawade1/operator-pattern-master/api/v1"
+)
+
+// ExampleReconciler reconciles a Example object
+type ExampleReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// ServiceMonitorsAvailable reports whether the Prometheus Operator CRDs
+	// are installed in the cluster. It is feature-detected once via the
+	// RESTMapper at startup (see ServiceMonitorCRDInstalled) and controls
+	// whether reconcileServiceMonitor creates anything.
+	ServiceMonitorsAvailable bool
+}
+
+// ServiceMonitorCRDInstalled feature-detects the Prometheus Operator
+// ServiceMonitor CRD via the given RESTMapper so callers never hard-require
+// the Prometheus Operator to be installed.
+func ServiceMonitorCRDInstalled(mapper meta.RESTMapper) (bool, error) {
+	_, err := mapper.RESTMapping(monitoringv1.SchemeGroupVersion.WithKind(monitoringv1.ServiceMonitorsKind).GroupKind(), monitoringv1.SchemeGroupVersion.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+//+kubebuilder:rbac:groups=example.example.com,resources=examples,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=example.example.com,resources=examples/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=example.example.com,resources=examples/finalizers,verbs=update
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile drives the actual state of an Example towards the desired state
+// by reconciling a Deployment and a Service from its spec.
+func (r *ExampleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	start := time.Now()
+	defer func() {
+		exampleReconcileDuration.Observe(time.Since(start).Seconds())
+		exampleReconcileTotal.WithLabelValues(reconcileResultLabel(reterr)).Inc()
+	}()
+
+	example := &examplev1.Example{}
+	if err := r.Get(ctx, req.NamespacedName, example); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting Example: %w", err)
+	}
+
+	patch := client.MergeFrom(example.DeepCopy())
+
+	telemetryErr := r.reconcileTelemetryConfigMap(ctx, example)
+
+	var deploy *appsv1.Deployment
+	var reconcileErr error
+	deploy, reconcileErr = r.reconcileDeployment(ctx, example, telemetryErr == nil)
+	if reconcileErr == nil {
+		reconcileErr = r.reconcileService(ctx, example)
+	}
+	if reconcileErr == nil {
+		reconcileErr = r.reconcileServiceMonitor(ctx, example)
+	}
+
+	r.updateStatus(example, deploy, reconcileErr)
+	r.updateTelemetryCondition(example, telemetryErr)
+	exampleReadyReplicas.WithLabelValues(example.Namespace, example.Name).Set(float64(example.Status.ReadyReplicas))
+
+	if err := r.Status().Patch(ctx, example, patch); err != nil {
+		return ctrl.Result{}, fmt.Errorf("patching Example status: %w", err)
+	}
+
+	if reconcileErr != nil {
+		return ctrl.Result{}, reconcileErr
+	}
+
+	log.Info("reconciled Example", "name", example.Name)
+	return ctrl.Result{}, nil
+}
+
+// reconcileResultLabel reports the "result" label value used by
+// example_reconcile_total for the given terminal reconcile error.
+func reconcileResultLabel(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
+
+// updateStatus derives Phase, Conditions, ReadyReplicas and Selector from the
+// reconciled Deployment and records the generation that was just observed.
+func (r *ExampleReconciler) updateStatus(example *examplev1.Example, deploy *appsv1.Deployment, reconcileErr error) {
+	example.Status.ObservedGeneration = example.Generation
+	example.Status.Selector = labels.SelectorFromSet(labelsForExample(example)).String()
+
+	if reconcileErr != nil {
+		example.Status.Phase = examplev1.PhaseFailed
+		meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+			Type: examplev1.ConditionDegraded, Status: metav1.ConditionTrue,
+			Reason: "ReconcileError", Message: reconcileErr.Error(),
+		})
+		meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+			Type: examplev1.ConditionAvailable, Status: metav1.ConditionFalse,
+			Reason: "ReconcileError", Message: reconcileErr.Error(),
+		})
+		return
+	}
+
+	var ready int32
+	var desired int32 = 1
+	if deploy != nil {
+		ready = deploy.Status.ReadyReplicas
+	}
+	if example.Spec.Replicas != nil {
+		desired = *example.Spec.Replicas
+	}
+	example.Status.ReadyReplicas = ready
+
+	meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+		Type: examplev1.ConditionDegraded, Status: metav1.ConditionFalse,
+		Reason: "ReconcileSucceeded", Message: "no reconcile errors",
+	})
+
+	if ready >= desired {
+		example.Status.Phase = examplev1.PhaseReady
+		meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+			Type: examplev1.ConditionAvailable, Status: metav1.ConditionTrue,
+			Reason: "MinimumReplicasAvailable", Message: "all replicas are ready",
+		})
+		meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+			Type: examplev1.ConditionProgressing, Status: metav1.ConditionFalse,
+			Reason: "ReplicaSetStable", Message: "replica set has reached the desired count",
+		})
+		return
+	}
+
+	example.Status.Phase = examplev1.PhasePending
+	meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+		Type: examplev1.ConditionAvailable, Status: metav1.ConditionFalse,
+		Reason: "WaitingForReplicas", Message: "waiting for replicas to become ready",
+	})
+	meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+		Type: examplev1.ConditionProgressing, Status: metav1.ConditionTrue,
+		Reason: "ReplicaSetUpdating", Message: "waiting for replicas to become ready",
+	})
+}
+
+// updateTelemetryCondition reports whether the OpenTelemetry Collector
+// sidecar, when requested, is configured and ready to be scheduled.
+func (r *ExampleReconciler) updateTelemetryCondition(example *examplev1.Example, telemetryErr error) {
+	if example.Spec.Telemetry == nil || !example.Spec.Telemetry.Enabled {
+		meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+			Type: examplev1.ConditionTelemetryReady, Status: metav1.ConditionFalse,
+			Reason: "TelemetryDisabled", Message: "telemetry is not enabled for this Example",
+		})
+		return
+	}
+	if telemetryErr != nil {
+		meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+			Type: examplev1.ConditionTelemetryReady, Status: metav1.ConditionFalse,
+			Reason: "InvalidConfig", Message: telemetryErr.Error(),
+		})
+		return
+	}
+	meta.SetStatusCondition(&example.Status.Conditions, metav1.Condition{
+		Type: examplev1.ConditionTelemetryReady, Status: metav1.ConditionTrue,
+		Reason: "CollectorConfigured", Message: "OpenTelemetry Collector sidecar config is valid",
+	})
+}
+
+// collectorConfigMapName returns the name of the ConfigMap holding the
+// generated OpenTelemetry Collector pipeline for example.
+func collectorConfigMapName(example *examplev1.Example) string {
+	return example.Name + "-otel-collector"
+}
+
+// reconcileTelemetryConfigMap creates or updates the ConfigMap backing the
+// OpenTelemetry Collector sidecar, validating the telemetry spec first so
+// invalid configuration is surfaced without ever touching the ConfigMap.
+func (r *ExampleReconciler) reconcileTelemetryConfigMap(ctx context.Context, example *examplev1.Example) error {
+	t := example.Spec.Telemetry
+	if t == nil || !t.Enabled {
+		return nil
+	}
+	if t.Endpoint == "" {
+		return fmt.Errorf("spec.telemetry.endpoint must be set when telemetry is enabled")
+	}
+	protocol := t.Protocol
+	if protocol == "" {
+		protocol = "grpc"
+	}
+	if protocol != "grpc" && protocol != "http" {
+		return fmt.Errorf("spec.telemetry.protocol %q must be \"grpc\" or \"http\"", protocol)
+	}
+	if t.SampleRatio != nil && (*t.SampleRatio < 0 || *t.SampleRatio > 1) {
+		return fmt.Errorf("spec.telemetry.sampleRatio %v must be between 0 and 1", *t.SampleRatio)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      collectorConfigMapName(example),
+			Namespace: example.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, cm, func() error {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data["config.yaml"] = collectorConfig(t, protocol)
+		return controllerutil.SetControllerReference(example, cm, r.Scheme)
+	})
+	return err
+}
+
+// collectorConfig renders an OTLP receiver + batch processor + OTLP exporter
+// pipeline for the embedded OpenTelemetry Collector sidecar.
+func collectorConfig(t *examplev1.TelemetrySpec, protocol string) string {
+	sampleRatio := 1.0
+	if t.SampleRatio != nil {
+		sampleRatio = *t.SampleRatio
+	}
+	return fmt.Sprintf(`receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+      http:
+        endpoint: 0.0.0.0:4318
+processors:
+  batch: {}
+  probabilistic_sampler:
+    sampling_percentage: %g
+exporters:
+  otlp:
+    endpoint: %s
+    protocol: %s
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [probabilistic_sampler, batch]
+      exporters: [otlp]
+    metrics:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [otlp]
+`, sampleRatio*100, t.Endpoint, protocol)
+}
+
+// reconcileDeployment reconciles the Deployment for example. telemetryReady
+// reports whether reconcileTelemetryConfigMap succeeded for this reconcile;
+// the collector sidecar and its ConfigMap volume are only injected when it
+// did, since otherwise the ConfigMap it would mount was never written.
+func (r *ExampleReconciler) reconcileDeployment(ctx context.Context, example *examplev1.Example, telemetryReady bool) (*appsv1.Deployment, error) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      example.Name,
+			Namespace: example.Namespace,
+		},
+	}
+
+	selector := labelsForExample(example)
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, deploy, func() error {
+		containers := []corev1.Container{
+			{
+				Name:            "workload",
+				Image:           example.Spec.Image,
+				ImagePullPolicy: example.Spec.ImagePullPolicy,
+				Env:             example.Spec.Env,
+				Resources:       example.Spec.Resources,
+			},
+		}
+		volumes := []corev1.Volume(nil)
+		if t := example.Spec.Telemetry; t != nil && t.Enabled && telemetryReady {
+			containers = append(containers, collectorSidecar(example))
+			volumes = append(volumes, corev1.Volume{
+				Name: "otel-collector-config",
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: collectorConfigMapName(example)},
+					},
+				},
+			})
+		}
+
+		deploy.Spec.Replicas = example.Spec.Replicas
+		deploy.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+		deploy.Spec.Template = corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: selector},
+			Spec: corev1.PodSpec{
+				ServiceAccountName: example.Spec.ServiceAccountName,
+				NodeSelector:       example.Spec.NodeSelector,
+				Tolerations:        example.Spec.Tolerations,
+				Affinity:           example.Spec.Affinity,
+				Containers:         containers,
+				Volumes:            volumes,
+			},
+		}
+		return controllerutil.SetControllerReference(example, deploy, r.Scheme)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deploy), deploy); err != nil {
+		return nil, err
+	}
+	return deploy, nil
+}
+
+// collectorSidecar builds the OpenTelemetry Collector container injected
+// alongside the workload container when Spec.Telemetry.Enabled is set.
+func collectorSidecar(example *examplev1.Example) corev1.Container {
+	t := example.Spec.Telemetry
+
+	headers := make([]string, 0, len(t.Headers))
+	for header := range t.Headers {
+		headers = append(headers, header)
+	}
+	sort.Strings(headers)
+
+	var env []corev1.EnvVar
+	for _, header := range headers {
+		env = append(env, corev1.EnvVar{
+			Name: "OTEL_HEADER_" + header,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: t.SecretRef},
+					Key:                  t.Headers[header],
+				},
+			},
+		})
+	}
+
+	return corev1.Container{
+		Name:  "otel-collector",
+		Image: "otel/opentelemetry-collector:0.96.0",
+		Args:  []string{"--config=/etc/otelcol/config.yaml"},
+		Env:   env,
+		Ports: []corev1.ContainerPort{
+			{Name: "otlp-grpc", ContainerPort: 4317},
+			{Name: "otlp-http", ContainerPort: 4318},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: "otel-collector-config", MountPath: "/etc/otelcol"},
+		},
+	}
+}
+
+func (r *ExampleReconciler) reconcileService(ctx context.Context, example *examplev1.Example) error {
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      example.Name,
+			Namespace: example.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, svc, func() error {
+		svc.Spec.Selector = labelsForExample(example)
+		ports := []corev1.ServicePort{
+			{Name: "http", Port: 80, TargetPort: intstr.FromInt(8080)},
+		}
+		if m := example.Spec.Metrics; m != nil && m.Enabled {
+			ports = append(ports, corev1.ServicePort{
+				Name: metricsPortName(m), Port: 8081, TargetPort: intstr.FromInt(8081),
+			})
+		}
+		svc.Spec.Ports = ports
+		return controllerutil.SetControllerReference(example, svc, r.Scheme)
+	})
+	return err
+}
+
+// metricsPortName returns the Service port name ServiceMonitor.Endpoints
+// scrapes, falling back to the "metrics" default from the CRD marker.
+func metricsPortName(m *examplev1.MetricsSpec) string {
+	if m.Port != "" {
+		return m.Port
+	}
+	return "metrics"
+}
+
+// reconcileServiceMonitor creates or updates a Prometheus ServiceMonitor for
+// the workload when metrics are enabled and the Prometheus Operator CRDs are
+// installed, without hard-requiring the Prometheus Operator.
+func (r *ExampleReconciler) reconcileServiceMonitor(ctx context.Context, example *examplev1.Example) error {
+	m := example.Spec.Metrics
+	if m == nil || !m.Enabled || !r.ServiceMonitorsAvailable {
+		return nil
+	}
+
+	path := m.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	interval := m.Interval
+	if interval == "" {
+		interval = "30s"
+	}
+
+	sm := &monitoringv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      example.Name,
+			Namespace: example.Namespace,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, sm, func() error {
+		sm.Spec.Selector = metav1.LabelSelector{MatchLabels: labelsForExample(example)}
+		sm.Spec.Endpoints = []monitoringv1.Endpoint{
+			{Port: metricsPortName(m), Path: path, Interval: monitoringv1.Duration(interval)},
+		}
+		return controllerutil.SetControllerReference(example, sm, r.Scheme)
+	})
+	return err
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ExampleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&examplev1.Example{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ConfigMap{})
+	if r.ServiceMonitorsAvailable {
+		bldr = bldr.Owns(&monitoringv1.ServiceMonitor{})
+	}
+	return bldr.Complete(r)
+}
+
+func labelsForExample(example *examplev1.Example) map[string]string {
+	return map[string]string{"app.kubernetes.io/instance": example.Name}
+}
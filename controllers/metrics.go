@@ -0,0 +1,28 @@
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	exampleReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "example_reconcile_total",
+		Help: "Total number of Example reconciles, by result.",
+	}, []string{"result"})
+
+	exampleReconcileDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "example_reconcile_duration_seconds",
+		Help:    "Duration of Example reconciles in seconds.",
+		Buckets: prometheus.DefBuckets,
+	})
+
+	exampleReadyReplicas = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "example_ready_replicas",
+		Help: "Ready replica count reported on an Example's status.",
+	}, []string{"namespace", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(exampleReconcileTotal, exampleReconcileDuration, exampleReadyReplicas)
+}
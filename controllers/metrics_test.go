@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	examplev1 "github.com/VishalGawade1/operator-pattern-master/api/v1"
+)
+
+func TestReconcileResultLabel(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"success": {err: nil, want: "success"},
+		"error":   {err: errTest, want: "error"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := reconcileResultLabel(tc.err); got != tc.want {
+				t.Errorf("reconcileResultLabel() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReconcileIncrementsTotalCounter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := examplev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding examplev1 to scheme: %v", err)
+	}
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding corev1 to scheme: %v", err)
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("adding appsv1 to scheme: %v", err)
+	}
+
+	example := &examplev1.Example{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+		Spec:       examplev1.ExampleSpec{Name: "test", Image: "nginx:latest"},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&examplev1.Example{}).
+		WithObjects(example).
+		Build()
+
+	r := &ExampleReconciler{Client: fakeClient, Scheme: scheme}
+
+	before := testutil.ToFloat64(exampleReconcileTotal.WithLabelValues("success"))
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: types.NamespacedName{Name: "test", Namespace: "default"},
+	})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	after := testutil.ToFloat64(exampleReconcileTotal.WithLabelValues("success"))
+	if after != before+1 {
+		t.Errorf("example_reconcile_total{result=success} increased by %v, want 1", after-before)
+	}
+}
+
+var errTest = &testError{"boom"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }